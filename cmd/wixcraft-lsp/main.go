@@ -0,0 +1,57 @@
+// Command wixcraft-lsp serves the wixcraft knowledge base as a Language
+// Server over stdio, so editors other than the Wails desktop app (VS Code,
+// Neovim, ...) can get WiX completion, hover and diagnostics.
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/Tsahi-Elkayam/wixcraft/internal/lsp"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	dbPath, err := findDatabase()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite3", dbPath+"?mode=ro")
+	if err != nil {
+		log.Fatalf("opening database: %v", err)
+	}
+	defer db.Close()
+
+	if err := lsp.Serve(os.Stdin, os.Stdout, db); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// findDatabase mirrors the search order the Wails app uses so a single
+// wix.db can serve both front ends.
+func findDatabase() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("getting executable path: %w", err)
+	}
+	homeDir, _ := os.UserHomeDir()
+
+	for _, p := range []string{
+		filepath.Join(filepath.Dir(exePath), "..", "data", "wix.db"),
+		filepath.Join(filepath.Dir(exePath), "data", "wix.db"),
+		filepath.Join(homeDir, ".wixcraft", "wix.db"),
+		"../common/wix-data/wix.db",
+		"../../common/wix-data/wix.db",
+		"common/wix-data/wix.db",
+		"data/wix.db",
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("could not find wix.db")
+}
@@ -0,0 +1,64 @@
+// Command wixcraft-server serves the wixcraft knowledge base as a REST
+// API, so web UIs and CI tooling can use it without the Wails desktop app.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/Tsahi-Elkayam/wixcraft/internal/httpapi"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	dbPath, err := findDatabase()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite3", dbPath+"?mode=ro")
+	if err != nil {
+		log.Fatalf("opening database: %v", err)
+	}
+	defer db.Close()
+
+	server, err := httpapi.NewServer(db, dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("wixcraft-server: using database %s, listening on %s", dbPath, *addr)
+	log.Fatal(server.ListenAndServe(*addr))
+}
+
+// findDatabase mirrors the search order the Wails app uses so a single
+// wix.db can serve every front end.
+func findDatabase() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("getting executable path: %w", err)
+	}
+	homeDir, _ := os.UserHomeDir()
+
+	for _, p := range []string{
+		filepath.Join(filepath.Dir(exePath), "..", "data", "wix.db"),
+		filepath.Join(filepath.Dir(exePath), "data", "wix.db"),
+		filepath.Join(homeDir, ".wixcraft", "wix.db"),
+		"../common/wix-data/wix.db",
+		"../../common/wix-data/wix.db",
+		"common/wix-data/wix.db",
+		"data/wix.db",
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("could not find wix.db")
+}
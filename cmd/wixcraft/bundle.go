@@ -0,0 +1,63 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Tsahi-Elkayam/wixcraft/internal/bundle"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func runBundle(args []string) {
+	fs := flag.NewFlagSet("bundle", flag.ExitOnError)
+	out := fs.String("out", "wixcraft-bundle.zip", "output archive path")
+	format := fs.String("format", "json", "record format: json or markdown")
+	fs.Parse(args)
+
+	dbPath, err := findDatabase()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wixcraft bundle:", err)
+		os.Exit(1)
+	}
+
+	db, err := sql.Open("sqlite3", dbPath+"?mode=ro")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wixcraft bundle: opening database:", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	if err := bundle.Export(db, *out, bundle.Format(*format)); err != nil {
+		fmt.Fprintln(os.Stderr, "wixcraft bundle:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("wixcraft bundle: wrote %s from %s\n", *out, dbPath)
+}
+
+// findDatabase mirrors the search order every other wixcraft front end uses.
+func findDatabase() (string, error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("getting executable path: %w", err)
+	}
+	homeDir, _ := os.UserHomeDir()
+
+	for _, p := range []string{
+		filepath.Join(filepath.Dir(exePath), "..", "data", "wix.db"),
+		filepath.Join(filepath.Dir(exePath), "data", "wix.db"),
+		filepath.Join(homeDir, ".wixcraft", "wix.db"),
+		"../common/wix-data/wix.db",
+		"../../common/wix-data/wix.db",
+		"common/wix-data/wix.db",
+		"data/wix.db",
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("could not find wix.db")
+}
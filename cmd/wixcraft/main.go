@@ -0,0 +1,30 @@
+// Command wixcraft is the wixcraft CLI: operations on the knowledge base
+// that don't need the Wails desktop app or a long-running server.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "bundle":
+		runBundle(os.Args[2:])
+	default:
+		fmt.Fprintf(os.Stderr, "wixcraft: unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wixcraft <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  bundle   export the knowledge base as a portable archive")
+}
@@ -0,0 +1,117 @@
+// Package bundle exports the whole wix.db knowledge base as a single
+// portable zip archive: one file per element/snippet/error/ICE
+// rule/lint rule, plus a top-level manifest. The result is meant to be
+// checked into a WiX project repo and grepped without the app, or diffed
+// across KB releases.
+package bundle
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Format selects how each record is rendered inside the archive.
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+)
+
+// Exporter walks db and writes an archive. It owns its own queries rather
+// than reusing App, matching internal/lint, internal/search and
+// internal/httpapi.
+type Exporter struct {
+	db *sql.DB
+}
+
+func NewExporter(db *sql.DB) *Exporter {
+	return &Exporter{db: db}
+}
+
+// manifest is the top-level index.json: counts (matching App.GetStats)
+// plus a SHA-256 per file so two bundle exports can be diffed for drift.
+type manifest struct {
+	Counts map[string]int    `json:"counts"`
+	Files  map[string]string `json:"files"`
+}
+
+// Export writes a zip archive to outPath containing the whole KB
+// rendered as format (FormatJSON or FormatMarkdown).
+func Export(db *sql.DB, outPath string, format Format) error {
+	if format != FormatJSON && format != FormatMarkdown {
+		return fmt.Errorf("bundle: unknown format %q (want %q or %q)", format, FormatJSON, FormatMarkdown)
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("bundle: creating %s: %w", outPath, err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	e := NewExporter(db)
+	man := manifest{Counts: map[string]int{}, Files: map[string]string{}}
+
+	for _, section := range []func(*zip.Writer, Format, *manifest) error{
+		e.writeElements,
+		e.writeSnippets,
+		e.writeErrors,
+		e.writeIceRules,
+		e.writeRules,
+	} {
+		if err := section(zw, format, &man); err != nil {
+			zw.Close()
+			return err
+		}
+	}
+
+	if err := writeJSONEntry(zw, "index.json", man); err != nil {
+		zw.Close()
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeEntry(zw *zip.Writer, path string, content []byte, man *manifest) error {
+	w, err := zw.Create(path)
+	if err != nil {
+		return fmt.Errorf("bundle: creating entry %s: %w", path, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("bundle: writing entry %s: %w", path, err)
+	}
+	sum := sha256.Sum256(content)
+	man.Files[path] = fmt.Sprintf("%x", sum)
+	return nil
+}
+
+func writeJSONEntry(zw *zip.Writer, path string, v interface{}) error {
+	data, err := marshalIndent(v)
+	if err != nil {
+		return fmt.Errorf("bundle: marshaling %s: %w", path, err)
+	}
+	w, err := zw.Create(path)
+	if err != nil {
+		return fmt.Errorf("bundle: creating entry %s: %w", path, err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func marshalIndent(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// countRows is the SQL equivalent of App.GetStats for one table, reused
+// to populate the manifest's counts.
+func countRows(db *sql.DB, table string) int {
+	var n int
+	db.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM %s`, table)).Scan(&n)
+	return n
+}
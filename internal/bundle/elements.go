@@ -0,0 +1,179 @@
+package bundle
+
+import (
+	"archive/zip"
+	"fmt"
+	"strings"
+)
+
+type attributeRecord struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Required     bool     `json:"required"`
+	DefaultValue string   `json:"default_value"`
+	Description  string   `json:"description"`
+	EnumValues   []string `json:"enum_values"`
+}
+
+// elementRef points at another element record, carrying its namespace so
+// cross-links (parents/children) resolve even when the two elements
+// don't share a namespace directory.
+type elementRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type elementRecord struct {
+	ID            int64             `json:"id"`
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	SinceVersion  string            `json:"since_version"`
+	Description   string            `json:"description"`
+	Documentation string            `json:"documentation"`
+	Remarks       string            `json:"remarks"`
+	Parents       []elementRef      `json:"parents"`
+	Children      []elementRef      `json:"children"`
+	Attributes    []attributeRecord `json:"attributes"`
+}
+
+func (e *Exporter) writeElements(zw *zip.Writer, format Format, man *manifest) error {
+	rows, err := e.db.Query(`
+		SELECT id, name, COALESCE(namespace, ''), COALESCE(since_version, ''),
+			COALESCE(description, ''), COALESCE(documentation_url, ''), COALESCE(remarks, '')
+		FROM elements ORDER BY namespace, name
+	`)
+	if err != nil {
+		return fmt.Errorf("bundle: reading elements: %w", err)
+	}
+	defer rows.Close()
+
+	man.Counts["elements"] = countRows(e.db, "elements")
+	man.Counts["attributes"] = countRows(e.db, "attributes")
+
+	for rows.Next() {
+		var rec elementRecord
+		if err := rows.Scan(&rec.ID, &rec.Name, &rec.Namespace, &rec.SinceVersion, &rec.Description, &rec.Documentation, &rec.Remarks); err != nil {
+			return fmt.Errorf("bundle: scanning element: %w", err)
+		}
+		rec.Parents = e.elementRefs(`
+			SELECT el.name, COALESCE(el.namespace, '') FROM elements el JOIN element_parents ep ON el.id = ep.parent_id
+			WHERE ep.element_id = ? ORDER BY el.name
+		`, rec.ID)
+		rec.Children = e.elementRefs(`
+			SELECT el.name, COALESCE(el.namespace, '') FROM elements el JOIN element_children ec ON el.id = ec.child_id
+			WHERE ec.element_id = ? ORDER BY el.name
+		`, rec.ID)
+		rec.Attributes = e.elementAttributes(rec.ID)
+
+		ns := namespaceDir(rec.Namespace)
+
+		var path string
+		var payload []byte
+		switch format {
+		case FormatJSON:
+			path = fmt.Sprintf("elements/%s/%s.json", ns, rec.Name)
+			if payload, err = marshalIndent(rec); err != nil {
+				return err
+			}
+		case FormatMarkdown:
+			path = fmt.Sprintf("elements/%s/%s.md", ns, rec.Name)
+			payload = []byte(renderElementMarkdown(rec))
+		}
+
+		if err := writeEntry(zw, path, payload, man); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) elementRefs(query string, elementID int64) []elementRef {
+	rows, err := e.db.Query(query, elementID)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var refs []elementRef
+	for rows.Next() {
+		var ref elementRef
+		if rows.Scan(&ref.Name, &ref.Namespace) == nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func (e *Exporter) elementAttributes(elementID int64) []attributeRecord {
+	rows, err := e.db.Query(`
+		SELECT name, COALESCE(attr_type, 'string'), COALESCE(required, 0),
+			COALESCE(default_value, ''), COALESCE(description, ''), COALESCE(enum_values, '')
+		FROM attributes WHERE element_id = ? ORDER BY required DESC, name
+	`, elementID)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var attrs []attributeRecord
+	for rows.Next() {
+		var a attributeRecord
+		var required int
+		var enumRaw string
+		if rows.Scan(&a.Name, &a.Type, &required, &a.DefaultValue, &a.Description, &enumRaw) != nil {
+			continue
+		}
+		a.Required = required == 1
+		if enumRaw != "" {
+			a.EnumValues = strings.Split(enumRaw, ",")
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
+
+func renderElementMarkdown(rec elementRecord) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", rec.Name)
+	if rec.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", rec.Description)
+	}
+	if rec.Remarks != "" {
+		fmt.Fprintf(&b, "%s\n\n", rec.Remarks)
+	}
+
+	if len(rec.Parents) > 0 {
+		b.WriteString("## Parents\n\n")
+		for _, p := range rec.Parents {
+			fmt.Fprintf(&b, "- [%s](../%s/%s.md)\n", p.Name, namespaceDir(p.Namespace), p.Name)
+		}
+		b.WriteString("\n")
+	}
+	if len(rec.Children) > 0 {
+		b.WriteString("## Children\n\n")
+		for _, c := range rec.Children {
+			fmt.Fprintf(&b, "- [%s](../%s/%s.md)\n", c.Name, namespaceDir(c.Namespace), c.Name)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(rec.Attributes) > 0 {
+		b.WriteString("## Attributes\n\n")
+		b.WriteString("| Name | Type | Required | Default | Description |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, a := range rec.Attributes {
+			fmt.Fprintf(&b, "| %s | %s | %v | %s | %s |\n", a.Name, a.Type, a.Required, a.DefaultValue, a.Description)
+		}
+	}
+
+	return b.String()
+}
+
+// namespaceDir maps a namespace to its directory component: elements with
+// no namespace live directly under elements/_/, and any other namespace
+// is used as-is.
+func namespaceDir(namespace string) string {
+	if namespace == "" {
+		return "_"
+	}
+	return namespace
+}
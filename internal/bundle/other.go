@@ -0,0 +1,203 @@
+package bundle
+
+import (
+	"archive/zip"
+	"fmt"
+	"strings"
+)
+
+type snippetRecord struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Prefix      string `json:"prefix"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+	Scope       string `json:"scope"`
+}
+
+func (e *Exporter) writeSnippets(zw *zip.Writer, format Format, man *manifest) error {
+	man.Counts["snippets"] = countRows(e.db, "snippets")
+
+	rows, err := e.db.Query(`
+		SELECT id, name, COALESCE(prefix, ''), COALESCE(description, ''), COALESCE(body, ''), COALESCE(scope, '')
+		FROM snippets ORDER BY name
+	`)
+	if err != nil {
+		return fmt.Errorf("bundle: reading snippets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec snippetRecord
+		if err := rows.Scan(&rec.ID, &rec.Name, &rec.Prefix, &rec.Description, &rec.Body, &rec.Scope); err != nil {
+			return fmt.Errorf("bundle: scanning snippet: %w", err)
+		}
+
+		var path string
+		var payload []byte
+		if format == FormatJSON {
+			path = fmt.Sprintf("snippets/%s.json", rec.Name)
+			if payload, err = marshalIndent(rec); err != nil {
+				return err
+			}
+		} else {
+			path = fmt.Sprintf("snippets/%s.md", rec.Name)
+			var b strings.Builder
+			fmt.Fprintf(&b, "# %s\n\n%s\n\nScope: `%s`\n\n```xml\n%s\n```\n", rec.Name, rec.Description, rec.Scope, rec.Body)
+			payload = []byte(b.String())
+		}
+		if err := writeEntry(zw, path, payload, man); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type errorRecord struct {
+	ID          int64  `json:"id"`
+	Code        string `json:"code"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+	Resolution  string `json:"resolution"`
+}
+
+func (e *Exporter) writeErrors(zw *zip.Writer, format Format, man *manifest) error {
+	man.Counts["errors"] = countRows(e.db, "errors")
+
+	rows, err := e.db.Query(`
+		SELECT id, code, COALESCE(severity, ''), COALESCE(message_template, ''),
+			COALESCE(description, ''), COALESCE(resolution, '')
+		FROM errors ORDER BY code
+	`)
+	if err != nil {
+		return fmt.Errorf("bundle: reading errors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec errorRecord
+		if err := rows.Scan(&rec.ID, &rec.Code, &rec.Severity, &rec.Message, &rec.Description, &rec.Resolution); err != nil {
+			return fmt.Errorf("bundle: scanning error: %w", err)
+		}
+
+		var path string
+		var payload []byte
+		if format == FormatJSON {
+			path = fmt.Sprintf("errors/%s.json", rec.Code)
+			if payload, err = marshalIndent(rec); err != nil {
+				return err
+			}
+		} else {
+			path = fmt.Sprintf("errors/%s.md", rec.Code)
+			var b strings.Builder
+			fmt.Fprintf(&b, "# %s (%s)\n\n%s\n\n**Message:** %s\n\n**Resolution:** %s\n", rec.Code, rec.Severity, rec.Description, rec.Message, rec.Resolution)
+			payload = []byte(b.String())
+		}
+		if err := writeEntry(zw, path, payload, man); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type iceRuleRecord struct {
+	ID          int64  `json:"id"`
+	Code        string `json:"code"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Tables      string `json:"tables"`
+	Resolution  string `json:"resolution"`
+}
+
+func (e *Exporter) writeIceRules(zw *zip.Writer, format Format, man *manifest) error {
+	man.Counts["ice_rules"] = countRows(e.db, "ice_rules")
+
+	rows, err := e.db.Query(`
+		SELECT id, code, COALESCE(severity, ''), COALESCE(description, ''),
+			COALESCE(tables_affected, ''), COALESCE(resolution, '')
+		FROM ice_rules ORDER BY code
+	`)
+	if err != nil {
+		return fmt.Errorf("bundle: reading ice_rules: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec iceRuleRecord
+		if err := rows.Scan(&rec.ID, &rec.Code, &rec.Severity, &rec.Description, &rec.Tables, &rec.Resolution); err != nil {
+			return fmt.Errorf("bundle: scanning ice_rule: %w", err)
+		}
+
+		var path string
+		var payload []byte
+		if format == FormatJSON {
+			path = fmt.Sprintf("ice-rules/%s.json", rec.Code)
+			if payload, err = marshalIndent(rec); err != nil {
+				return err
+			}
+		} else {
+			path = fmt.Sprintf("ice-rules/%s.md", rec.Code)
+			var b strings.Builder
+			fmt.Fprintf(&b, "# %s (%s)\n\n%s\n\n**Tables affected:** %s\n\n**Resolution:** %s\n", rec.Code, rec.Severity, rec.Description, rec.Tables, rec.Resolution)
+			payload = []byte(b.String())
+		}
+		if err := writeEntry(zw, path, payload, man); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ruleRecord struct {
+	ID            int64  `json:"id"`
+	RuleID        string `json:"rule_id"`
+	Category      string `json:"category"`
+	Severity      string `json:"severity"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Rationale     string `json:"rationale"`
+	FixSuggestion string `json:"fix_suggestion"`
+	TargetName    string `json:"target_name"`
+}
+
+func (e *Exporter) writeRules(zw *zip.Writer, format Format, man *manifest) error {
+	man.Counts["rules"] = countRows(e.db, "rules")
+
+	rows, err := e.db.Query(`
+		SELECT id, rule_id, COALESCE(category, ''), COALESCE(severity, ''), COALESCE(name, ''),
+			COALESCE(description, ''), COALESCE(rationale, ''), COALESCE(fix_suggestion, ''), COALESCE(target_name, '')
+		FROM rules ORDER BY rule_id
+	`)
+	if err != nil {
+		return fmt.Errorf("bundle: reading rules: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rec ruleRecord
+		if err := rows.Scan(&rec.ID, &rec.RuleID, &rec.Category, &rec.Severity, &rec.Name,
+			&rec.Description, &rec.Rationale, &rec.FixSuggestion, &rec.TargetName); err != nil {
+			return fmt.Errorf("bundle: scanning rule: %w", err)
+		}
+
+		var path string
+		var payload []byte
+		if format == FormatJSON {
+			path = fmt.Sprintf("rules/%s.json", rec.RuleID)
+			if payload, err = marshalIndent(rec); err != nil {
+				return err
+			}
+		} else {
+			path = fmt.Sprintf("rules/%s.md", rec.RuleID)
+			var b strings.Builder
+			fmt.Fprintf(&b, "# %s: %s\n\nCategory: `%s` · Severity: `%s` · Target: `%s`\n\n%s\n\n**Rationale:** %s\n\n**Fix:** %s\n",
+				rec.RuleID, rec.Name, rec.Category, rec.Severity, rec.TargetName, rec.Description, rec.Rationale, rec.FixSuggestion)
+			payload = []byte(b.String())
+		}
+		if err := writeEntry(zw, path, payload, man); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package httpapi
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// route is the single source of truth for both mux registration and the
+// generated OpenAPI document, so the two can never drift apart. The
+// /healthz and /openapi.json endpoints are not routes and are not in it.
+type route struct {
+	method  string
+	pattern string
+	summary string
+	handler http.HandlerFunc
+}
+
+func (s *Server) routeTable() []route {
+	return []route{
+		{"GET", "/namespaces/{ns}/elements", "List elements in a namespace", s.handleNamespaceElements},
+		{"GET", "/elements/{name}", "Get an element by name", s.handleElement},
+		{"GET", "/elements/{name}/attributes", "List an element's attributes", s.handleElementAttributes},
+		{"GET", "/elements/{name}/children", "List an element's allowed children", s.handleElementChildren},
+		{"GET", "/errors/{code}", "Get a WiX error by code", s.handleError},
+		{"GET", "/ice-rules/{code}", "Get an ICE rule by code", s.handleIceRule},
+		{"GET", "/rules", "List lint rule categories", s.handleRules},
+		{"GET", "/rules/{id}", "Get a lint rule by rule_id", s.handleRule},
+		{"GET", "/snippets", "List snippet names", s.handleSnippets},
+		{"GET", "/snippets/{name}", "Get a snippet by name", s.handleSnippet},
+		{"GET", "/search", "Full-text search across the KB", s.handleSearch},
+		{"GET", "/stats", "Get KB row counts", s.handleStats},
+	}
+}
+
+// Element is the REST representation of a WiX element. It mirrors
+// tools/wix-help's ElementInfo; httpapi keeps its own copy rather than
+// importing a `main` package.
+type Element struct {
+	ID            int64       `json:"id"`
+	Name          string      `json:"name"`
+	Namespace     string      `json:"namespace"`
+	SinceVersion  string      `json:"since_version"`
+	Description   string      `json:"description"`
+	Documentation string      `json:"documentation"`
+	Remarks       string      `json:"remarks"`
+	Parents       []string    `json:"parents"`
+	Children      []string    `json:"children"`
+	Attributes    []Attribute `json:"attributes"`
+}
+
+type Attribute struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Required     bool     `json:"required"`
+	DefaultValue string   `json:"default_value"`
+	Description  string   `json:"description"`
+	EnumValues   []string `json:"enum_values"`
+}
+
+func (s *Server) loadElement(name string) *Element {
+	var e Element
+	err := s.db.QueryRow(`
+		SELECT id, name, COALESCE(namespace, ''), COALESCE(since_version, ''),
+			COALESCE(description, ''), COALESCE(documentation_url, ''), COALESCE(remarks, '')
+		FROM elements WHERE name = ?
+	`, name).Scan(&e.ID, &e.Name, &e.Namespace, &e.SinceVersion, &e.Description, &e.Documentation, &e.Remarks)
+	if err != nil {
+		return nil
+	}
+	e.Parents = s.elementParents(e.ID)
+	e.Children = s.elementChildren(e.ID)
+	e.Attributes = s.elementAttributes(e.ID)
+	return &e
+}
+
+func (s *Server) elementParents(id int64) []string {
+	return queryStrings(s.db, `
+		SELECT e.name FROM elements e JOIN element_parents ep ON e.id = ep.parent_id
+		WHERE ep.element_id = ? ORDER BY e.name
+	`, id)
+}
+
+func (s *Server) elementChildren(id int64) []string {
+	return queryStrings(s.db, `
+		SELECT e.name FROM elements e JOIN element_children ec ON e.id = ec.child_id
+		WHERE ec.element_id = ? ORDER BY e.name
+	`, id)
+}
+
+func (s *Server) elementAttributes(id int64) []Attribute {
+	rows, err := s.db.Query(`
+		SELECT name, COALESCE(attr_type, 'string'), COALESCE(required, 0),
+			COALESCE(default_value, ''), COALESCE(description, ''), COALESCE(enum_values, '')
+		FROM attributes WHERE element_id = ? ORDER BY required DESC, name
+	`, id)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var attrs []Attribute
+	for rows.Next() {
+		var a Attribute
+		var required int
+		var enumRaw string
+		if rows.Scan(&a.Name, &a.Type, &required, &a.DefaultValue, &a.Description, &enumRaw) != nil {
+			continue
+		}
+		a.Required = required == 1
+		if enumRaw != "" {
+			a.EnumValues = strings.Split(enumRaw, ",")
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
+
+func queryStrings(db *sql.DB, sqlStr string, args ...interface{}) []string {
+	rows, err := db.Query(sqlStr, args...)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var v string
+		if rows.Scan(&v) == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (s *Server) handleNamespaceElements(w http.ResponseWriter, r *http.Request) {
+	ns := r.PathValue("ns")
+	names := queryStrings(s.db, `SELECT name FROM elements WHERE namespace = ? ORDER BY name`, ns)
+	writeJSON(w, http.StatusOK, project(r, names))
+}
+
+func (s *Server) handleElement(w http.ResponseWriter, r *http.Request) {
+	e := s.loadElement(r.PathValue("name"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, "element not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, e))
+}
+
+func (s *Server) handleElementAttributes(w http.ResponseWriter, r *http.Request) {
+	e := s.loadElement(r.PathValue("name"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, "element not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, e.Attributes))
+}
+
+func (s *Server) handleElementChildren(w http.ResponseWriter, r *http.Request) {
+	e := s.loadElement(r.PathValue("name"))
+	if e == nil {
+		writeError(w, http.StatusNotFound, "element not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, e.Children))
+}
+
+type Error struct {
+	ID          int64  `json:"id"`
+	Code        string `json:"code"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+	Resolution  string `json:"resolution"`
+}
+
+func (s *Server) handleError(w http.ResponseWriter, r *http.Request) {
+	var e Error
+	err := s.db.QueryRow(`
+		SELECT id, code, COALESCE(severity, ''), COALESCE(message_template, ''),
+			COALESCE(description, ''), COALESCE(resolution, '')
+		FROM errors WHERE code = ?
+	`, r.PathValue("code")).Scan(&e.ID, &e.Code, &e.Severity, &e.Message, &e.Description, &e.Resolution)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "error code not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, e))
+}
+
+type IceRule struct {
+	ID          int64  `json:"id"`
+	Code        string `json:"code"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Tables      string `json:"tables"`
+	Resolution  string `json:"resolution"`
+}
+
+func (s *Server) handleIceRule(w http.ResponseWriter, r *http.Request) {
+	var ice IceRule
+	err := s.db.QueryRow(`
+		SELECT id, code, COALESCE(severity, ''), COALESCE(description, ''),
+			COALESCE(tables_affected, ''), COALESCE(resolution, '')
+		FROM ice_rules WHERE code = ?
+	`, r.PathValue("code")).Scan(&ice.ID, &ice.Code, &ice.Severity, &ice.Description, &ice.Tables, &ice.Resolution)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "ICE rule not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, ice))
+}
+
+type Rule struct {
+	ID            int64  `json:"id"`
+	RuleID        string `json:"rule_id"`
+	Category      string `json:"category"`
+	Severity      string `json:"severity"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Rationale     string `json:"rationale"`
+	FixSuggestion string `json:"fix_suggestion"`
+	TargetName    string `json:"target_name"`
+}
+
+func (s *Server) handleRules(w http.ResponseWriter, r *http.Request) {
+	categories := queryStrings(s.db, `SELECT DISTINCT category FROM rules WHERE category != '' ORDER BY category`)
+	writeJSON(w, http.StatusOK, project(r, categories))
+}
+
+func (s *Server) handleRule(w http.ResponseWriter, r *http.Request) {
+	var rule Rule
+	err := s.db.QueryRow(`
+		SELECT id, rule_id, COALESCE(category, ''), COALESCE(severity, ''),
+			COALESCE(name, ''), COALESCE(description, ''),
+			COALESCE(rationale, ''), COALESCE(fix_suggestion, ''), COALESCE(target_name, '')
+		FROM rules WHERE rule_id = ?
+	`, r.PathValue("id")).Scan(&rule.ID, &rule.RuleID, &rule.Category, &rule.Severity, &rule.Name,
+		&rule.Description, &rule.Rationale, &rule.FixSuggestion, &rule.TargetName)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "rule not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, rule))
+}
+
+type Snippet struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Prefix      string `json:"prefix"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+	Scope       string `json:"scope"`
+}
+
+func (s *Server) handleSnippets(w http.ResponseWriter, r *http.Request) {
+	names := queryStrings(s.db, `SELECT name FROM snippets ORDER BY name`)
+	writeJSON(w, http.StatusOK, project(r, names))
+}
+
+func (s *Server) handleSnippet(w http.ResponseWriter, r *http.Request) {
+	var sn Snippet
+	err := s.db.QueryRow(`
+		SELECT id, name, COALESCE(prefix, ''), COALESCE(description, ''),
+			COALESCE(body, ''), COALESCE(scope, '')
+		FROM snippets WHERE name = ?
+	`, r.PathValue("name")).Scan(&sn.ID, &sn.Name, &sn.Prefix, &sn.Description, &sn.Body, &sn.Scope)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "snippet not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project(r, sn))
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	if kind := r.URL.Query().Get("kind"); kind != "" && !strings.Contains(q, "kind:") {
+		q = "kind:" + kind + " " + q
+	}
+	writeJSON(w, http.StatusOK, project(r, s.search.Search(q)))
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	stats := make(map[string]int)
+	for table, key := range map[string]string{
+		"elements": "elements", "attributes": "attributes", "snippets": "snippets",
+		"errors": "errors", "ice_rules": "ice_rules", "rules": "rules",
+	} {
+		var count int
+		s.db.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&count)
+		stats[key] = count
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// project applies the `fields=` query filter, projecting v down to the
+// requested JSON keys. Without a `fields` param it returns v unchanged.
+func project(r *http.Request, v interface{}) interface{} {
+	fields := r.URL.Query().Get("fields")
+	if fields == "" {
+		return v
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var full map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &full); err != nil {
+		// Not an object (e.g. a []string list) - fields filtering doesn't apply.
+		return v
+	}
+
+	wanted := strings.Split(fields, ",")
+	filtered := make(map[string]json.RawMessage, len(wanted))
+	for _, f := range wanted {
+		f = strings.TrimSpace(f)
+		if val, ok := full[f]; ok {
+			filtered[f] = val
+		}
+	}
+	return filtered
+}
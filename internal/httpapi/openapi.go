@@ -0,0 +1,49 @@
+package httpapi
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// handleOpenAPI generates a minimal OpenAPI 3.0 document straight from
+// routeTable, so every routeTable endpoint is listed exactly as it is
+// registered. /healthz and /openapi.json are registered outside
+// routeTable and are not listed.
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	paths := make(map[string]interface{})
+
+	for _, rt := range s.routeTable() {
+		methods, _ := paths[rt.pattern].(map[string]interface{})
+		if methods == nil {
+			methods = make(map[string]interface{})
+			paths[rt.pattern] = methods
+		}
+
+		var params []map[string]interface{}
+		for _, name := range pathParamPattern.FindAllStringSubmatch(rt.pattern, -1) {
+			params = append(params, map[string]interface{}{
+				"name":     name[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]string{"type": "string"},
+			})
+		}
+
+		methods[strings.ToLower(rt.method)] = map[string]interface{}{
+			"summary":    rt.summary,
+			"parameters": params,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{"description": "OK"},
+			},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    map[string]string{"title": "wixcraft knowledge base API", "version": "1"},
+		"paths":   paths,
+	})
+}
@@ -0,0 +1,97 @@
+// Package httpapi exposes the wix.db knowledge base as a REST API, so web
+// UIs and CI tooling can use it without the Wails desktop shell. It owns
+// its own database access and sub-engines (mirroring internal/lint and
+// internal/search) rather than reusing App, since App lives in a `main`
+// package and can't be imported.
+package httpapi
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Tsahi-Elkayam/wixcraft/internal/lint"
+	"github.com/Tsahi-Elkayam/wixcraft/internal/search"
+)
+
+// Server holds the dependencies every handler needs.
+type Server struct {
+	db     *sql.DB
+	linter *lint.Linter
+	search *search.Engine
+	etag   string
+}
+
+// NewServer builds a Server backed by db. dbPath is used to compute an
+// ETag from the database file's mtime so clients can send
+// `If-None-Match` and skip re-fetching an unchanged KB.
+func NewServer(db *sql.DB, dbPath string) (*Server, error) {
+	linter, err := lint.NewLinter(db)
+	if err != nil {
+		return nil, fmt.Errorf("httpapi: loading linter schema: %w", err)
+	}
+
+	s := &Server{
+		db:     db,
+		linter: linter,
+		search: search.NewEngine(db),
+		etag:   etagFor(dbPath),
+	}
+	return s, nil
+}
+
+func etagFor(dbPath string) string {
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().Unix(), info.Size())
+}
+
+// Routes builds the *http.ServeMux every handler is registered on. It is
+// exported separately from ListenAndServe so callers (tests, or a process
+// that wants to layer its own middleware) can mount it themselves.
+func (s *Server) Routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, rt := range s.routeTable() {
+		mux.HandleFunc(rt.method+" "+rt.pattern, s.withETag(rt.handler))
+	}
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	return mux
+}
+
+// ListenAndServe starts the REST API on addr. It blocks until the server
+// stops or an error occurs, matching net/http.ListenAndServe.
+func (s *Server) ListenAndServe(addr string) error {
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      s.Routes(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+func (s *Server) withETag(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.etag != "" {
+			w.Header().Set("ETag", s.etag)
+			if r.Header.Get("If-None-Match") == s.etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	resp := map[string]string{"status": "ok"}
+	if degraded := s.search.Degraded(); degraded != "" {
+		resp["search_degraded"] = degraded
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
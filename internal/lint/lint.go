@@ -0,0 +1,259 @@
+// Package lint cross-references a user's .wxs/.wxi XML source against the
+// element/attribute/rule tables in wix.db and reports diagnostics.
+package lint
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Severity is the diagnostic severity, mirroring the `severity` columns
+// already used by the `errors`, `ice_rules` and `rules` tables.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Position is a 1-based line/column, matching the convention used by most
+// editors. The LSP `Position` type is 0-based, so internal/lsp subtracts 1
+// from both when publishing diagnostics.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Range is the span of source a Diagnostic applies to.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// RuleRef is a lightweight pointer back to the `rules` row a diagnostic
+// came from. The frontend resolves it to a full RuleInfo via App.GetRule.
+type RuleRef struct {
+	RuleID        string `json:"rule_id"`
+	Category      string `json:"category"`
+	Name          string `json:"name"`
+	Rationale     string `json:"rationale"`
+	FixSuggestion string `json:"fix_suggestion"`
+}
+
+// Diagnostic describes a single lint finding. RelatedRules lists the KB
+// best-practice rules that target the same element/attribute, for
+// context in an editor tooltip — they are not themselves evaluated, so
+// they ride along on the diagnostic that actually was, rather than
+// appearing as findings of their own.
+type Diagnostic struct {
+	Code         string    `json:"code"`
+	Severity     Severity  `json:"severity"`
+	Message      string    `json:"message"`
+	Range        Range     `json:"range"`
+	RelatedRules []RuleRef `json:"related_rules,omitempty"`
+}
+
+// Linter holds the in-memory schema snapshot used to check a document
+// without round-tripping to the database per element.
+type Linter struct {
+	elements      map[string]elementSchema
+	rulesByTarget map[string][]ruleSchema
+}
+
+// NewLinter loads the element/attribute/rule schema from db. The returned
+// Linter is safe to reuse across LintSource/LintFile calls; it does not
+// observe later writes to db.
+func NewLinter(db *sql.DB) (*Linter, error) {
+	elements, err := loadElements(db)
+	if err != nil {
+		return nil, fmt.Errorf("lint: loading elements: %w", err)
+	}
+	rules, err := loadRules(db)
+	if err != nil {
+		return nil, fmt.Errorf("lint: loading rules: %w", err)
+	}
+	return &Linter{elements: elements, rulesByTarget: rules}, nil
+}
+
+// LintFile reads path and lints its contents.
+func (l *Linter) LintFile(path string) ([]Diagnostic, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lint: reading %s: %w", path, err)
+	}
+	return l.LintSource(string(data)), nil
+}
+
+// LintSource lints an in-memory .wxs/.wxi document.
+func (l *Linter) LintSource(source string) []Diagnostic {
+	var diags []Diagnostic
+
+	lineStarts := computeLineStarts(source)
+	dec := xml.NewDecoder(strings.NewReader(source))
+
+	type frame struct {
+		name string
+		seen map[string]bool
+	}
+	var stack []frame
+
+	for {
+		startOffset := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			diags = append(diags, Diagnostic{
+				Code:     "WX0001",
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("XML parse error: %v", err),
+				Range:    pointRange(lineStarts, startOffset),
+			})
+			break
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			rng := pointRange(lineStarts, startOffset)
+
+			schema, known := l.elements[name]
+			if !known {
+				diags = append(diags, Diagnostic{
+					Code:     "WX0002",
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("unknown element <%s>", name),
+					Range:    rng,
+				})
+			} else {
+				if len(stack) > 0 && len(schema.parents) > 0 && !schema.parents[stack[len(stack)-1].name] {
+					diags = append(diags, Diagnostic{
+						Code:         "WX0003",
+						Severity:     SeverityError,
+						Message:      fmt.Sprintf("<%s> is not valid inside <%s>", name, stack[len(stack)-1].name),
+						Range:        rng,
+						RelatedRules: l.ruleRefs(name),
+					})
+				}
+				diags = append(diags, l.checkAttributes(name, schema, t.Attr, rng)...)
+			}
+
+			stack = append(stack, frame{name: name, seen: map[string]bool{}})
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return diags
+}
+
+// checkAttributes validates attrs against schema.
+func (l *Linter) checkAttributes(elementName string, schema elementSchema, attrs []xml.Attr, rng Range) []Diagnostic {
+	var diags []Diagnostic
+	seen := make(map[string]bool, len(attrs))
+
+	for _, attr := range attrs {
+		name := attr.Name.Local
+		seen[name] = true
+
+		aschema, ok := schema.attributes[name]
+		if !ok {
+			continue
+		}
+
+		if len(aschema.enumValues) > 0 && !isVariableExpr(attr.Value) && !containsString(aschema.enumValues, attr.Value) {
+			diags = append(diags, Diagnostic{
+				Code:         "WX0004",
+				Severity:     SeverityWarning,
+				Message:      fmt.Sprintf("%s=%q on <%s> is not one of %s", name, attr.Value, elementName, strings.Join(aschema.enumValues, ", ")),
+				Range:        rng,
+				RelatedRules: l.ruleRefs(name),
+			})
+		}
+		if !matchesType(aschema.attrType, attr.Value) {
+			diags = append(diags, Diagnostic{
+				Code:         "WX0006",
+				Severity:     SeverityWarning,
+				Message:      fmt.Sprintf("%s=%q on <%s> is not a valid %s", name, attr.Value, elementName, aschema.attrType),
+				Range:        rng,
+				RelatedRules: l.ruleRefs(name),
+			})
+		}
+	}
+
+	// Required attributes are reported in a stable order for reproducible output.
+	var missing []string
+	for name, aschema := range schema.attributes {
+		if aschema.required && !seen[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		diags = append(diags, Diagnostic{
+			Code:         "WX0005",
+			Severity:     SeverityError,
+			Message:      fmt.Sprintf("<%s> is missing required attribute %q", elementName, name),
+			Range:        rng,
+			RelatedRules: l.ruleRefs(elementName),
+		})
+	}
+
+	return diags
+}
+
+// ruleRefs converts the KB rules targeting name into RuleRefs for
+// Diagnostic.RelatedRules. These rules aren't evaluated against the
+// document; they're surfaced as context on a diagnostic that was.
+func (l *Linter) ruleRefs(name string) []RuleRef {
+	rules := l.rulesByTarget[name]
+	if len(rules) == 0 {
+		return nil
+	}
+	refs := make([]RuleRef, len(rules))
+	for i, rule := range rules {
+		refs[i] = RuleRef{
+			RuleID:        rule.RuleID,
+			Category:      rule.Category,
+			Name:          rule.Name,
+			Rationale:     rule.Rationale,
+			FixSuggestion: rule.FixSuggestion,
+		}
+	}
+	return refs
+}
+
+// computeLineStarts returns the byte offset each line begins at, so a byte
+// offset from xml.Decoder.InputOffset can be turned into a Position.
+func computeLineStarts(source string) []int {
+	starts := []int{0}
+	for i, b := range []byte(source) {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+func offsetToPosition(lineStarts []int, offset int) Position {
+	line := sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > offset }) - 1
+	if line < 0 {
+		line = 0
+	}
+	return Position{Line: line + 1, Column: offset - lineStarts[line] + 1}
+}
+
+func pointRange(lineStarts []int, offset int) Range {
+	pos := offsetToPosition(lineStarts, offset)
+	return Range{Start: pos, End: pos}
+}
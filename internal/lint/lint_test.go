@@ -0,0 +1,86 @@
+package lint
+
+import "testing"
+
+// newTestLinter builds a Linter from literal schema data instead of a
+// *sql.DB, mirroring what NewLinter assembles from wix.db.
+func newTestLinter() *Linter {
+	return &Linter{
+		elements: map[string]elementSchema{
+			"Wix":       {name: "Wix", parents: map[string]bool{}, attributes: map[string]attrSchema{}},
+			"Directory": {name: "Directory", parents: map[string]bool{}, attributes: map[string]attrSchema{}},
+			"Component": {
+				name:    "Component",
+				parents: map[string]bool{"Directory": true},
+				attributes: map[string]attrSchema{
+					"Guid": {required: true, attrType: "guid"},
+				},
+			},
+		},
+		rulesByTarget: map[string][]ruleSchema{
+			"Component": {{
+				RuleID:     "WXR001",
+				Category:   "best-practice",
+				Severity:   "warning",
+				Name:       "Components should declare a stable Guid",
+				TargetName: "Component",
+			}},
+		},
+	}
+}
+
+func codes(diags []Diagnostic) []string {
+	out := make([]string, len(diags))
+	for i, d := range diags {
+		out[i] = d.Code
+	}
+	return out
+}
+
+func TestLintSourceUnknownElement(t *testing.T) {
+	diags := newTestLinter().LintSource(`<Bogus/>`)
+	if got := codes(diags); len(got) != 1 || got[0] != "WX0002" {
+		t.Fatalf("LintSource unknown element = %v, want [WX0002]", got)
+	}
+}
+
+func TestLintSourceValidDocument(t *testing.T) {
+	diags := newTestLinter().LintSource(`<Directory><Component Guid="{12345678-1234-1234-1234-123456789012}"/></Directory>`)
+	if len(diags) != 0 {
+		t.Fatalf("LintSource valid document = %v, want no diagnostics", diags)
+	}
+}
+
+func TestLintSourceInvalidParent(t *testing.T) {
+	diags := newTestLinter().LintSource(`<Wix><Component Guid="{12345678-1234-1234-1234-123456789012}"/></Wix>`)
+	if got := codes(diags); len(got) != 1 || got[0] != "WX0003" {
+		t.Fatalf("LintSource invalid parent = %v, want [WX0003]", got)
+	}
+	if rr := diags[0].RelatedRules; len(rr) != 1 || rr[0].RuleID != "WXR001" {
+		t.Fatalf("LintSource invalid parent RelatedRules = %v, want [WXR001]", rr)
+	}
+}
+
+func TestLintSourceMissingRequiredAttribute(t *testing.T) {
+	diags := newTestLinter().LintSource(`<Directory><Component/></Directory>`)
+	if got := codes(diags); len(got) != 1 || got[0] != "WX0005" {
+		t.Fatalf("LintSource missing required attribute = %v, want [WX0005]", got)
+	}
+	if rr := diags[0].RelatedRules; len(rr) != 1 || rr[0].RuleID != "WXR001" {
+		t.Fatalf("LintSource missing required attribute RelatedRules = %v, want [WXR001]", rr)
+	}
+}
+
+func TestLintSourceInvalidGuidValue(t *testing.T) {
+	diags := newTestLinter().LintSource(`<Directory><Component Guid="not-a-guid"/></Directory>`)
+	if got := codes(diags); len(got) != 1 || got[0] != "WX0006" {
+		t.Fatalf("LintSource invalid guid value = %v, want [WX0006]", got)
+	}
+}
+
+func TestLintSourceAutoGuidSentinelIsValid(t *testing.T) {
+	diags := newTestLinter().LintSource(`<Directory><Component Guid="*"/></Directory>`)
+	if len(diags) != 0 {
+		t.Fatalf("LintSource auto-guid sentinel = %v, want no diagnostics", diags)
+	}
+}
@@ -0,0 +1,205 @@
+package lint
+
+import (
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// attrSchema describes what the linter knows about a single attribute of
+// an element, pulled from the `attributes` table.
+type attrSchema struct {
+	required   bool
+	attrType   string
+	enumValues []string
+}
+
+// elementSchema describes what the linter knows about a single element,
+// pulled from the `elements`/`element_parents`/`attributes` tables.
+type elementSchema struct {
+	name       string
+	parents    map[string]bool
+	attributes map[string]attrSchema
+}
+
+// ruleSchema is the subset of the `rules` table the linter attaches to
+// diagnostics as RelatedRules, keyed by target element/attribute name.
+// The rules themselves are not evaluated against the document.
+type ruleSchema struct {
+	RuleID        string
+	Category      string
+	Severity      string
+	Name          string
+	Rationale     string
+	FixSuggestion string
+	TargetName    string
+}
+
+// loadElements builds the element name -> schema index used for the
+// "unknown element", "invalid parent" and attribute checks.
+func loadElements(db *sql.DB) (map[string]elementSchema, error) {
+	elements := make(map[string]elementSchema)
+	ids := make(map[int64]string)
+
+	rows, err := db.Query(`SELECT id, name FROM elements`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids[id] = name
+		elements[name] = elementSchema{name: name, parents: map[string]bool{}, attributes: map[string]attrSchema{}}
+	}
+	rows.Close()
+
+	parentRows, err := db.Query(`
+		SELECT ep.element_id, e.name FROM element_parents ep
+		JOIN elements e ON e.id = ep.parent_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for parentRows.Next() {
+		var elementID int64
+		var parentName string
+		if err := parentRows.Scan(&elementID, &parentName); err != nil {
+			parentRows.Close()
+			return nil, err
+		}
+		if name, ok := ids[elementID]; ok {
+			elements[name].parents[parentName] = true
+		}
+	}
+	parentRows.Close()
+
+	attrRows, err := db.Query(`
+		SELECT element_id, name, COALESCE(attr_type, 'string'), COALESCE(required, 0), COALESCE(enum_values, '')
+		FROM attributes
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for attrRows.Next() {
+		var elementID int64
+		var name, attrType, enumRaw string
+		var required int
+		if err := attrRows.Scan(&elementID, &name, &attrType, &required, &enumRaw); err != nil {
+			attrRows.Close()
+			return nil, err
+		}
+		if elName, ok := ids[elementID]; ok {
+			elements[elName].attributes[name] = attrSchema{
+				required:   required == 1,
+				attrType:   attrType,
+				enumValues: splitEnumValues(enumRaw),
+			}
+		}
+	}
+	attrRows.Close()
+
+	return elements, nil
+}
+
+// loadRules builds the TargetName -> rules index used to fill
+// Diagnostic.RelatedRules. No diagnostic is produced from a rule alone.
+func loadRules(db *sql.DB) (map[string][]ruleSchema, error) {
+	byTarget := make(map[string][]ruleSchema)
+
+	rows, err := db.Query(`
+		SELECT rule_id, COALESCE(category, ''), COALESCE(severity, ''), COALESCE(name, ''),
+			COALESCE(rationale, ''), COALESCE(fix_suggestion, ''), COALESCE(target_name, '')
+		FROM rules
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r ruleSchema
+		if err := rows.Scan(&r.RuleID, &r.Category, &r.Severity, &r.Name, &r.Rationale, &r.FixSuggestion, &r.TargetName); err != nil {
+			return nil, err
+		}
+		if r.TargetName == "" {
+			continue
+		}
+		byTarget[r.TargetName] = append(byTarget[r.TargetName], r)
+	}
+	return byTarget, nil
+}
+
+func splitEnumValues(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	values := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if v := strings.TrimSpace(p); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func containsString(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
+
+var guidPattern = regexp.MustCompile(`^\{?[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}\}?$`)
+
+// isVariableExpr reports whether value is a WiX preprocessor/binder
+// expression (`$(var.X)`, `!(bind.X)`, `!(loc.X)`), a property reference
+// (`[PROP]`), an environment variable (`%VAR%`), or `*` (the guid
+// attribute's auto-generate sentinel) rather than a literal. None of
+// these can be checked against attrType or an enum until build time, so
+// the schema checks must treat them as valid.
+func isVariableExpr(value string) bool {
+	if value == "*" {
+		return true
+	}
+	for _, prefix := range []string{"$(", "!(", "[", "%"} {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesType reports whether value is well-formed for attrType, the
+// `attr_type` column on `attributes`. Unknown/empty types are treated as
+// unconstrained strings rather than rejected, since attr_type is free text
+// and new values shouldn't make the linter start failing closed.
+func matchesType(attrType, value string) bool {
+	if isVariableExpr(value) {
+		return true
+	}
+	switch strings.ToLower(attrType) {
+	case "", "string", "path", "version", "identifier":
+		return true
+	case "int", "integer":
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case "bool", "yesno", "yesnotype":
+		switch strings.ToLower(value) {
+		case "yes", "no", "true", "false", "1", "0":
+			return true
+		}
+		return false
+	case "guid":
+		return guidPattern.MatchString(value)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,37 @@
+package lint
+
+import "testing"
+
+func TestMatchesType(t *testing.T) {
+	cases := []struct {
+		name     string
+		attrType string
+		value    string
+		want     bool
+	}{
+		{"empty type accepts anything", "", "whatever", true},
+		{"string accepts anything", "string", "whatever", true},
+		{"int accepts digits", "int", "42", true},
+		{"int rejects non-numeric", "int", "abc", false},
+		{"guid accepts well-formed guid", "guid", "12345678-1234-1234-1234-123456789012", true},
+		{"guid accepts braced guid", "guid", "{12345678-1234-1234-1234-123456789012}", true},
+		{"guid rejects malformed value", "guid", "not-a-guid", false},
+		{"guid accepts auto-generate sentinel", "guid", "*", true},
+		{"yesno accepts yes/no", "yesno", "yes", true},
+		{"yesno accepts true/false/1/0", "yesno", "true", true},
+		{"YesNoType accepts 0", "YesNoType", "0", true},
+		{"yesno rejects other values", "yesno", "maybe", false},
+		{"typed attribute accepts preprocessor variable", "int", "$(var.Count)", true},
+		{"typed attribute accepts binder expression", "guid", "!(bind.FileId)", true},
+		{"typed attribute accepts loc expression", "int", "!(loc.Count)", true},
+		{"typed attribute accepts property reference", "int", "[INSTALLLEVEL]", true},
+		{"typed attribute accepts environment variable", "int", "%BUILD_NUMBER%", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := matchesType(c.attrType, c.value); got != c.want {
+				t.Errorf("matchesType(%q, %q) = %v, want %v", c.attrType, c.value, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+)
+
+var tagPattern = regexp.MustCompile(`<(/?)([A-Za-z][\w.-]*)([^>]*)>`)
+
+// completionKind distinguishes what a completion request is asking for,
+// derived from the text immediately before the cursor.
+type completionKind int
+
+const (
+	completeElement completionKind = iota
+	completeAttribute
+	completeAttributeValue
+)
+
+// completionCtx is what the schema lookup needs to answer a completion
+// request: the enclosing element (for child/attribute lookups) and, for
+// attribute-value completion, which attribute is being filled in.
+type completionCtx struct {
+	kind      completionKind
+	element   string
+	attribute string
+}
+
+// resolveCompletionContext inspects the text up to the cursor offset and
+// figures out whether the user is completing a child element, an
+// attribute name, or an attribute value, and which element/attribute that
+// applies to.
+func resolveCompletionContext(text string, offset int) completionCtx {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	prefix := text[:offset]
+
+	lastLT := strings.LastIndexByte(prefix, '<')
+	lastGT := strings.LastIndexByte(prefix, '>')
+
+	if lastLT > lastGT {
+		fragment := prefix[lastLT:]
+		tagName := currentTagName(fragment)
+		if strings.Count(fragment, `"`)%2 == 1 {
+			return completionCtx{kind: completeAttributeValue, element: tagName, attribute: currentAttributeName(fragment)}
+		}
+		return completionCtx{kind: completeAttribute, element: tagName}
+	}
+
+	return completionCtx{kind: completeElement, element: enclosingElement(prefix)}
+}
+
+func currentTagName(fragment string) string {
+	fragment = strings.TrimPrefix(fragment, "<")
+	end := strings.IndexAny(fragment, " \t\r\n")
+	if end == -1 {
+		return fragment
+	}
+	return fragment[:end]
+}
+
+// currentAttributeName finds the attribute whose value is being typed,
+// i.e. the name immediately before the still-open quote at the end of
+// fragment.
+func currentAttributeName(fragment string) string {
+	open := strings.LastIndexByte(fragment, '"')
+	before := fragment[:open]
+	eq := strings.LastIndexByte(before, '=')
+	if eq == -1 {
+		return ""
+	}
+	name := strings.TrimRight(before[:eq], " \t\r\n")
+	start := strings.LastIndexAny(name, " \t\r\n")
+	return name[start+1:]
+}
+
+// enclosingElement walks every complete start/end tag in prefix with a
+// stack, ignoring self-closing tags, and returns the name of whichever
+// element the cursor currently sits inside (empty for the document root).
+func enclosingElement(prefix string) string {
+	var stack []string
+	for _, m := range tagPattern.FindAllStringSubmatch(prefix, -1) {
+		closing, name, attrs := m[1] == "/", m[2], m[3]
+		switch {
+		case closing:
+			if len(stack) > 0 && stack[len(stack)-1] == name {
+				stack = stack[:len(stack)-1]
+			}
+		case strings.HasSuffix(strings.TrimSpace(attrs), "/"):
+			// self-closing, no-op
+		default:
+			stack = append(stack, name)
+		}
+	}
+	if len(stack) == 0 {
+		return ""
+	}
+	return stack[len(stack)-1]
+}
+
+func offsetForPosition(text string, pos position) int {
+	line, col := 0, 0
+	for i, r := range text {
+		if line == pos.Line && col == pos.Character {
+			return i
+		}
+		if r == '\n' {
+			line++
+			col = 0
+		} else {
+			col++
+		}
+	}
+	return len(text)
+}
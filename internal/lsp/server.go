@@ -0,0 +1,340 @@
+// Package lsp implements a Language Server Protocol front end over the same
+// wix.db knowledge base App serves to the Wails UI, so editors that speak
+// LSP (VS Code, Neovim, ...) can get completion, hover and diagnostics
+// without going through the desktop app.
+package lsp
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/Tsahi-Elkayam/wixcraft/internal/lint"
+)
+
+// Server holds per-connection state: the read-only KB handle, the shared
+// linter, and the text of whatever documents the client has open.
+type Server struct {
+	db     *sql.DB
+	linter *lint.Linter
+	conn   *conn
+
+	documents map[string]string
+}
+
+// Serve runs the LSP server to completion, reading JSON-RPC requests from r
+// and writing responses/notifications to w until the client sends `exit`
+// or the connection is closed.
+func Serve(r io.Reader, w io.Writer, db *sql.DB) error {
+	linter, err := lint.NewLinter(db)
+	if err != nil {
+		return fmt.Errorf("lsp: loading linter schema: %w", err)
+	}
+
+	s := &Server{
+		db:        db,
+		linter:    linter,
+		conn:      newConn(r, w),
+		documents: map[string]string{},
+	}
+	return s.loop()
+}
+
+func (s *Server) loop() error {
+	for {
+		req, err := s.conn.readMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, rpcErr := s.dispatch(req.Method, req.Params)
+
+		// A request has an ID; a notification does not and gets no reply.
+		if req.ID == nil {
+			if rpcErr != nil {
+				log.Printf("lsp: notification %s: %v", req.Method, rpcErr)
+			}
+			continue
+		}
+		if rpcErr != nil {
+			if err := s.conn.replyError(req.ID, codeInternalError, rpcErr.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.conn.reply(req.ID, result); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) dispatch(method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1, // full document sync
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{"<", " ", "\""}},
+				"hoverProvider":      true,
+				"executeCommandProvider": map[string]interface{}{
+					"commands": []string{"wixcraft.insertSnippet"},
+				},
+			},
+		}, nil
+
+	case "initialized", "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		s.documents[p.TextDocument.URI] = p.TextDocument.Text
+		s.publishDiagnostics(p.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if len(p.ContentChanges) > 0 {
+			// Full sync: the last change event carries the whole document.
+			s.documents[p.TextDocument.URI] = p.ContentChanges[len(p.ContentChanges)-1].Text
+		}
+		s.publishDiagnostics(p.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		delete(s.documents, p.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/completion":
+		var p textDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return s.completion(p), nil
+
+	case "textDocument/hover":
+		var p textDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return s.hover(p), nil
+
+	case "workspace/executeCommand":
+		var p executeCommandParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return s.executeCommand(p)
+
+	default:
+		return nil, fmt.Errorf("method not found: %s", method)
+	}
+}
+
+func (s *Server) publishDiagnostics(uri string) {
+	text := s.documents[uri]
+	diags := s.linter.LintSource(text)
+
+	lspDiags := make([]lspDiagnostic, 0, len(diags))
+	for _, d := range diags {
+		lspDiags = append(lspDiags, lspDiagnostic{
+			Range: rangeT{
+				Start: position{Line: d.Range.Start.Line - 1, Character: d.Range.Start.Column - 1},
+				End:   position{Line: d.Range.End.Line - 1, Character: d.Range.End.Column - 1},
+			},
+			Severity: severityToLSP(d.Severity),
+			Code:     d.Code,
+			Source:   "wixcraft",
+			Message:  d.Message,
+		})
+	}
+
+	if err := s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: lspDiags}); err != nil {
+		log.Printf("lsp: publishDiagnostics: %v", err)
+	}
+}
+
+func severityToLSP(sev lint.Severity) int {
+	switch sev {
+	case lint.SeverityError:
+		return severityError
+	case lint.SeverityInfo:
+		return severityInformation
+	default:
+		return severityWarning
+	}
+}
+
+func (s *Server) completion(p textDocumentPositionParams) completionList {
+	text := s.documents[p.TextDocument.URI]
+	offset := offsetForPosition(text, p.Position)
+	ctx := resolveCompletionContext(text, offset)
+
+	switch ctx.kind {
+	case completeElement:
+		return completionList{Items: s.elementItems(ctx.element)}
+	case completeAttribute:
+		return completionList{Items: s.attributeItems(ctx.element)}
+	case completeAttributeValue:
+		return completionList{Items: s.enumItems(ctx.element, ctx.attribute)}
+	default:
+		return completionList{}
+	}
+}
+
+func (s *Server) elementItems(parent string) []completionItem {
+	var rows *sql.Rows
+	var err error
+	if parent == "" {
+		rows, err = s.db.Query(`
+			SELECT name, COALESCE(description, '') FROM elements e
+			WHERE NOT EXISTS (SELECT 1 FROM element_parents ep WHERE ep.element_id = e.id)
+			ORDER BY name
+		`)
+	} else {
+		rows, err = s.db.Query(`
+			SELECT c.name, COALESCE(c.description, '') FROM elements p
+			JOIN element_children ec ON ec.element_id = p.id
+			JOIN elements c ON c.id = ec.child_id
+			WHERE p.name = ?
+			ORDER BY c.name
+		`, parent)
+	}
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var items []completionItem
+	for rows.Next() {
+		var name, desc string
+		if rows.Scan(&name, &desc) == nil {
+			items = append(items, completionItem{Label: name, Kind: completionItemKindClass, Detail: desc})
+		}
+	}
+	return items
+}
+
+func (s *Server) attributeItems(element string) []completionItem {
+	rows, err := s.db.Query(`
+		SELECT a.name, COALESCE(a.required, 0), COALESCE(a.description, '')
+		FROM attributes a
+		JOIN elements e ON e.id = a.element_id
+		WHERE e.name = ?
+		ORDER BY a.required DESC, a.name
+	`, element)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var items []completionItem
+	for rows.Next() {
+		var name, desc string
+		var required int
+		if rows.Scan(&name, &required, &desc) != nil {
+			continue
+		}
+		detail := desc
+		if required == 1 {
+			detail = "(required) " + detail
+		}
+		items = append(items, completionItem{Label: name, Kind: completionItemKindField, Detail: detail})
+	}
+	return items
+}
+
+func (s *Server) enumItems(element, attribute string) []completionItem {
+	var enumRaw string
+	err := s.db.QueryRow(`
+		SELECT COALESCE(a.enum_values, '') FROM attributes a
+		JOIN elements e ON e.id = a.element_id
+		WHERE e.name = ? AND a.name = ?
+	`, element, attribute).Scan(&enumRaw)
+	if err != nil || enumRaw == "" {
+		return nil
+	}
+
+	var items []completionItem
+	for _, v := range strings.Split(enumRaw, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			items = append(items, completionItem{Label: v, Kind: completionItemKindEnum})
+		}
+	}
+	return items
+}
+
+func (s *Server) hover(p textDocumentPositionParams) *hover {
+	text := s.documents[p.TextDocument.URI]
+	offset := offsetForPosition(text, p.Position)
+	ctx := resolveCompletionContext(text, offset)
+
+	element := ctx.element
+	if ctx.kind == completeElement {
+		// Hovering between tags means the cursor is inside the parent; the
+		// thing being hovered is more useful described via the nearest tag.
+		element = enclosingElement(text[:offset])
+	}
+	if element == "" {
+		return nil
+	}
+
+	var description, documentation, remarks string
+	err := s.db.QueryRow(`
+		SELECT COALESCE(description, ''), COALESCE(documentation_url, ''), COALESCE(remarks, '')
+		FROM elements WHERE name = ?
+	`, element).Scan(&description, &documentation, &remarks)
+	if err != nil {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**\n\n%s", element, description)
+	if remarks != "" {
+		fmt.Fprintf(&b, "\n\n%s", remarks)
+	}
+	if documentation != "" {
+		fmt.Fprintf(&b, "\n\n[documentation](%s)", documentation)
+	}
+
+	return &hover{Contents: markupContent{Kind: "markdown", Value: b.String()}}
+}
+
+func (s *Server) executeCommand(p executeCommandParams) (interface{}, error) {
+	if p.Command != "wixcraft.insertSnippet" {
+		return nil, fmt.Errorf("unknown command: %s", p.Command)
+	}
+	if len(p.Arguments) < 1 {
+		return nil, fmt.Errorf("wixcraft.insertSnippet requires a snippet name argument")
+	}
+
+	var body string
+	if err := s.db.QueryRow(`SELECT COALESCE(body, '') FROM snippets WHERE name = ?`, p.Arguments[0]).Scan(&body); err != nil {
+		return nil, fmt.Errorf("unknown snippet %q", p.Arguments[0])
+	}
+
+	return map[string]interface{}{
+		"insertText":       toLSPSnippet(body),
+		"insertTextFormat": insertTextFormatSnippet,
+	}, nil
+}
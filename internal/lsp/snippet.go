@@ -0,0 +1,25 @@
+package lsp
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// placeholderPattern matches the `{{name}}` tabstop markers used in
+// SnippetInfo.Body, and the bare `{{}}` marker for the final cursor stop.
+var placeholderPattern = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+// toLSPSnippet converts a SnippetInfo.Body into an LSP `SnippetString`:
+// each `{{name}}` becomes a numbered, labelled tabstop `${n:name}` and a
+// bare `{{}}` becomes the final cursor stop `$0`.
+func toLSPSnippet(body string) string {
+	tabstop := 0
+	return placeholderPattern.ReplaceAllStringFunc(body, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if name == "" {
+			return "$0"
+		}
+		tabstop++
+		return "${" + strconv.Itoa(tabstop) + ":" + name + "}"
+	})
+}
@@ -0,0 +1,25 @@
+package lsp
+
+import "testing"
+
+func TestToLSPSnippet(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"no placeholders", "plain text", "plain text"},
+		{"single tabstop", `<Foo Bar="{{value}}" />`, `<Foo Bar="${1:value}" />`},
+		{"multiple tabstops numbered in order", "{{a}} {{b}} {{c}}", "${1:a} ${2:b} ${3:c}"},
+		{"final cursor stop", "{{a}}{{}}", "${1:a}$0"},
+		{"only final cursor stop", "text{{}}", "text$0"},
+		{"empty body", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := toLSPSnippet(c.body); got != c.want {
+				t.Errorf("toLSPSnippet(%q) = %q, want %q", c.body, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package search
+
+import "strings"
+
+// searchLike is the original substring scan, kept as the fallback path
+// for wix.db files old enough not to support the in-memory FTS5 index.
+func (e *Engine) searchLike(query string) []Result {
+	var results []Result
+	likeQuery := "%" + strings.ToLower(query) + "%"
+
+	rows, _ := e.src.Query(`
+		SELECT name, 'element', namespace, COALESCE(description, '')
+		FROM elements
+		WHERE LOWER(name) LIKE ? OR LOWER(description) LIKE ?
+		LIMIT 20
+	`, likeQuery, likeQuery)
+	if rows != nil {
+		for rows.Next() {
+			var r Result
+			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
+			r.ID = "element:" + r.Name
+			results = append(results, r)
+		}
+		rows.Close()
+	}
+
+	rows, _ = e.src.Query(`
+		SELECT name, 'snippet', COALESCE(scope, ''), COALESCE(description, '')
+		FROM snippets
+		WHERE LOWER(name) LIKE ? OR LOWER(description) LIKE ?
+		LIMIT 10
+	`, likeQuery, likeQuery)
+	if rows != nil {
+		for rows.Next() {
+			var r Result
+			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
+			r.ID = "snippet:" + r.Name
+			results = append(results, r)
+		}
+		rows.Close()
+	}
+
+	rows, _ = e.src.Query(`
+		SELECT code, 'wix-error', severity, COALESCE(description, '')
+		FROM errors
+		WHERE LOWER(code) LIKE ? OR LOWER(description) LIKE ?
+		LIMIT 10
+	`, likeQuery, likeQuery)
+	if rows != nil {
+		for rows.Next() {
+			var r Result
+			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
+			r.ID = "error:" + r.Name
+			results = append(results, r)
+		}
+		rows.Close()
+	}
+
+	rows, _ = e.src.Query(`
+		SELECT rule_id, 'rule', COALESCE(category, ''), COALESCE(description, '')
+		FROM rules
+		WHERE LOWER(rule_id) LIKE ? OR LOWER(name) LIKE ? OR LOWER(description) LIKE ?
+		LIMIT 10
+	`, likeQuery, likeQuery, likeQuery)
+	if rows != nil {
+		for rows.Next() {
+			var r Result
+			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
+			r.ID = "rule:" + r.Name
+			results = append(results, r)
+		}
+		rows.Close()
+	}
+
+	return results
+}
@@ -0,0 +1,289 @@
+// Package search ranks and serves full-text queries over the wix.db
+// knowledge base. It prefers an FTS5 index (built in-memory at startup,
+// since wix.db itself is opened read-only) and falls back to the
+// original LIKE-based scan when the index can't be built, so existing
+// read-only wix.db files keep working.
+//
+// FTS5 ranking requires github.com/mattn/go-sqlite3 to be built with the
+// sqlite_fts5 tag:
+//
+//	go build -tags sqlite_fts5 ./...
+//
+// Without that tag, SQLite's FTS5 module is compiled out and every
+// Engine degrades to the LIKE-based fallback: Score stays 0, Snippet is
+// empty, and kind:/xml:/prefix/phrase query syntax is not interpreted:
+// the whole query, filters and quotes included, is matched as one
+// literal substring. Call
+// Degraded to check for this (and to tell it apart from the unrelated
+// case of a wix.db whose elements/attributes/snippets tables are missing
+// or too old to read).
+package search
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Result is a single search hit, ready to render in the tree/search UI.
+type Result struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Category string  `json:"category"`
+	Synopsis string  `json:"synopsis"`
+	Score    float64 `json:"score"`
+	Snippet  string  `json:"snippet,omitempty"`
+}
+
+// Engine answers Search queries against a wix.db handle.
+type Engine struct {
+	src      *sql.DB
+	fts      *sql.DB // in-memory FTS5 index; nil when it couldn't be built
+	degraded string  // non-empty explanation when running on the LIKE fallback
+}
+
+// fts5ModuleMissing reports whether err is SQLite rejecting `USING fts5`
+// because the driver was compiled without the sqlite_fts5 build tag, as
+// opposed to any other failure building the index.
+func fts5ModuleMissing(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such module: fts5")
+}
+
+// NewEngine builds the FTS5 index from src. If indexing fails, the
+// Engine falls back to LIKE-based search transparently, but records why
+// via Degraded instead of only logging it once at startup: a driver
+// built without sqlite_fts5 degrades every Engine for the life of the
+// process, which is worth surfacing through /healthz and SearchStatus, not
+// just a line in the startup log.
+func NewEngine(src *sql.DB) *Engine {
+	e := &Engine{src: src}
+	if err := e.buildIndex(); err != nil {
+		if fts5ModuleMissing(err) {
+			e.degraded = "sqlite3 driver built without FTS5 support: rebuild with `go build -tags sqlite_fts5 ./...` for ranked search"
+		} else {
+			e.degraded = fmt.Sprintf("FTS5 index unavailable: %v", err)
+		}
+		log.Printf("search: %s; falling back to LIKE search", e.degraded)
+		if e.fts != nil {
+			e.fts.Close()
+			e.fts = nil
+		}
+	}
+	return e
+}
+
+// Degraded returns a non-empty explanation when Search is running on the
+// LIKE-based fallback instead of the ranked FTS5 index, distinguishing a
+// driver built without sqlite_fts5 (every Engine is affected; rebuild
+// with the tag) from an indexing failure specific to this wix.db (e.g.
+// an older schema missing a table the indexer reads).
+func (e *Engine) Degraded() string {
+	return e.degraded
+}
+
+type indexSource struct {
+	kind  string
+	query string
+}
+
+var indexSources = []indexSource{
+	{"element", `SELECT id, name, COALESCE(description, '') || ' ' || COALESCE(documentation_url, '') FROM elements`},
+	{"attribute", `SELECT id, name, COALESCE(description, '') FROM attributes`},
+	{"snippet", `SELECT id, name, COALESCE(description, '') || ' ' || COALESCE(body, '') FROM snippets`},
+	{"error", `SELECT id, code, COALESCE(description, '') || ' ' || COALESCE(message_template, '') FROM errors`},
+	{"ice_rule", `SELECT id, code, COALESCE(description, '') FROM ice_rules`},
+	{"rule", `SELECT id, rule_id, COALESCE(name, '') || ' ' || COALESCE(description, '') || ' ' || COALESCE(rationale, '') FROM rules`},
+}
+
+func (e *Engine) buildIndex() error {
+	fts, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return fmt.Errorf("opening in-memory index: %w", err)
+	}
+	e.fts = fts
+
+	if _, err := fts.Exec(`
+		CREATE VIRTUAL TABLE search_index USING fts5(
+			name, body, kind UNINDEXED, ref_id UNINDEXED,
+			tokenize = 'porter unicode61'
+		)
+	`); err != nil {
+		return fmt.Errorf("creating search_index: %w", err)
+	}
+
+	insert, err := fts.Prepare(`INSERT INTO search_index (name, body, kind, ref_id) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("preparing insert: %w", err)
+	}
+	defer insert.Close()
+
+	for _, s := range indexSources {
+		rows, err := e.src.Query(s.query)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", s.kind, err)
+		}
+		for rows.Next() {
+			var id int64
+			var name, body string
+			if err := rows.Scan(&id, &name, &body); err != nil {
+				rows.Close()
+				return fmt.Errorf("scanning %s: %w", s.kind, err)
+			}
+			if _, err := insert.Exec(name, body, s.kind, id); err != nil {
+				rows.Close()
+				return fmt.Errorf("indexing %s %q: %w", s.kind, name, err)
+			}
+		}
+		rows.Close()
+	}
+	return nil
+}
+
+// Search ranks query against the FTS5 index and returns up to 30 results.
+// query supports quoted phrases ("exact phrase"), trailing-* prefixes
+// (wix*), and field filters: `kind:element` restricts to one result kind,
+// `xml:name` matches only the name column. If the index is unavailable it
+// scans with LIKE instead: up to 20 elements and 10 each of snippets,
+// errors and rules, unranked; attributes and ICE rules are not searched.
+func (e *Engine) Search(query string) []Result {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	if e.fts == nil {
+		return e.searchLike(query)
+	}
+
+	ftsQuery, kind := parseQuery(query)
+	if ftsQuery == "" {
+		return nil
+	}
+
+	sqlQuery := `
+		SELECT name, kind, ref_id, bm25(search_index, 10.0, 1.0), snippet(search_index, 1, '[', ']', '...', 12)
+		FROM search_index WHERE search_index MATCH ?`
+	args := []interface{}{ftsQuery}
+	if kind != "" {
+		sqlQuery += ` AND kind = ?`
+		args = append(args, kind)
+	}
+	sqlQuery += ` ORDER BY bm25(search_index, 10.0, 1.0) LIMIT 30`
+
+	rows, err := e.fts.Query(sqlQuery, args...)
+	if err != nil {
+		// A malformed MATCH expression (unbalanced quotes, bad syntax) is a
+		// user-input problem, not a missing-index problem: fail quietly.
+		return nil
+	}
+	defer rows.Close()
+
+	var results []Result
+	for rows.Next() {
+		var name, rowKind, snippet string
+		var refID int64
+		var bm25 float64
+		if rows.Scan(&name, &rowKind, &refID, &bm25, &snippet) != nil {
+			continue
+		}
+		if r := e.resolve(rowKind, refID, name, -bm25, snippet); r != nil {
+			results = append(results, *r)
+		}
+	}
+	return results
+}
+
+// resolve turns an indexed (kind, ref_id) hit back into a full Result,
+// matching the ID/Type/Category conventions the existing tree/search UI
+// already expects.
+func (e *Engine) resolve(kind string, refID int64, name string, score float64, snippet string) *Result {
+	switch kind {
+	case "element":
+		var namespace string
+		if e.src.QueryRow(`SELECT COALESCE(namespace, '') FROM elements WHERE id = ?`, refID).Scan(&namespace) != nil {
+			return nil
+		}
+		return &Result{ID: "element:" + name, Name: name, Type: "element", Category: namespace, Synopsis: snippet, Score: score, Snippet: snippet}
+
+	case "attribute":
+		var elementName string
+		if e.src.QueryRow(`SELECT e.name FROM attributes a JOIN elements e ON e.id = a.element_id WHERE a.id = ?`, refID).Scan(&elementName) != nil {
+			return nil
+		}
+		return &Result{ID: "element:" + elementName, Name: name, Type: "attribute", Category: elementName, Synopsis: snippet, Score: score, Snippet: snippet}
+
+	case "snippet":
+		var scope string
+		if e.src.QueryRow(`SELECT COALESCE(scope, '') FROM snippets WHERE id = ?`, refID).Scan(&scope) != nil {
+			return nil
+		}
+		return &Result{ID: "snippet:" + name, Name: name, Type: "snippet", Category: scope, Synopsis: snippet, Score: score, Snippet: snippet}
+
+	case "error":
+		var severity string
+		if e.src.QueryRow(`SELECT COALESCE(severity, '') FROM errors WHERE id = ?`, refID).Scan(&severity) != nil {
+			return nil
+		}
+		return &Result{ID: "error:" + name, Name: name, Type: "wix-error", Category: severity, Synopsis: snippet, Score: score, Snippet: snippet}
+
+	case "ice_rule":
+		var severity string
+		if e.src.QueryRow(`SELECT COALESCE(severity, '') FROM ice_rules WHERE id = ?`, refID).Scan(&severity) != nil {
+			return nil
+		}
+		return &Result{ID: "ice:" + name, Name: name, Type: "ice-rule", Category: severity, Synopsis: snippet, Score: score, Snippet: snippet}
+
+	case "rule":
+		var category string
+		if e.src.QueryRow(`SELECT COALESCE(category, '') FROM rules WHERE id = ?`, refID).Scan(&category) != nil {
+			return nil
+		}
+		return &Result{ID: "rule:" + name, Name: name, Type: "rule", Category: category, Synopsis: snippet, Score: score, Snippet: snippet}
+	}
+	return nil
+}
+
+// parseQuery splits raw into an FTS5 MATCH expression and an optional
+// `kind:` filter, translating the `xml:name` field filter into FTS5's
+// native `name:term` column filter syntax.
+func parseQuery(raw string) (ftsQuery string, kind string) {
+	var terms []string
+	for _, tok := range tokenize(raw) {
+		switch {
+		case strings.HasPrefix(tok, "kind:"):
+			kind = strings.TrimPrefix(tok, "kind:")
+		case strings.HasPrefix(tok, "xml:"):
+			terms = append(terms, "name:"+strings.TrimPrefix(tok, "xml:"))
+		default:
+			terms = append(terms, tok)
+		}
+	}
+	return strings.Join(terms, " "), kind
+}
+
+// tokenize splits on spaces while keeping double-quoted phrases
+// intact, since FTS5 interprets `"exact phrase"` itself.
+func tokenize(raw string) []string {
+	var tokens []string
+	var b strings.Builder
+	inQuotes := false
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			b.WriteRune(r)
+		case r == ' ' && !inQuotes:
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return tokens
+}
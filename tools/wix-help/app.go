@@ -6,15 +6,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 
+	"github.com/Tsahi-Elkayam/wixcraft/internal/bundle"
+	"github.com/Tsahi-Elkayam/wixcraft/internal/httpapi"
+	"github.com/Tsahi-Elkayam/wixcraft/internal/lint"
+	"github.com/Tsahi-Elkayam/wixcraft/internal/search"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // App struct
 type App struct {
-	ctx context.Context
-	db  *sql.DB
+	ctx    context.Context
+	db     *sql.DB
+	dbPath string
+	linter *lint.Linter
+	search *search.Engine
+	index  *elementIndex
 }
 
 // NewApp creates a new App application struct
@@ -66,6 +74,23 @@ func (a *App) startup(ctx context.Context) {
 		return
 	}
 	a.db = db
+	a.dbPath = dbPath
+
+	linter, err := lint.NewLinter(db)
+	if err != nil {
+		fmt.Println("Error loading linter schema:", err)
+		return
+	}
+	a.linter = linter
+
+	a.search = search.NewEngine(db)
+
+	index, err := a.buildIndex()
+	if err != nil {
+		fmt.Println("Error building element index:", err)
+		return
+	}
+	a.index = index
 }
 
 // shutdown is called when the app is closing
@@ -151,15 +176,6 @@ type RuleInfo struct {
 	TargetName    string `json:"target_name"`
 }
 
-// SearchResult represents a search result
-type SearchResult struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Type     string `json:"type"`
-	Category string `json:"category"`
-	Synopsis string `json:"synopsis"`
-}
-
 // GetSidebarTree returns the sidebar tree structure
 func (a *App) GetSidebarTree() []TreeNode {
 	tree := []TreeNode{}
@@ -223,6 +239,10 @@ func (a *App) GetSidebarTree() []TreeNode {
 }
 
 func (a *App) getNamespaces() []string {
+	if a.index != nil {
+		return a.index.namespaces
+	}
+
 	var namespaces []string
 	rows, err := a.db.Query(`SELECT DISTINCT namespace FROM elements WHERE namespace != '' ORDER BY namespace`)
 	if err != nil {
@@ -239,6 +259,10 @@ func (a *App) getNamespaces() []string {
 }
 
 func (a *App) getElementsByNamespace(namespace string) []string {
+	if a.index != nil {
+		return a.index.byNamespace[namespace]
+	}
+
 	var elements []string
 	rows, err := a.db.Query(`SELECT name FROM elements WHERE namespace = ? ORDER BY name`, namespace)
 	if err != nil {
@@ -303,6 +327,15 @@ func (a *App) getIceRuleCodes() []string {
 }
 
 func (a *App) getRuleCategories() []string {
+	if a.index != nil {
+		categories := make([]string, 0, len(a.index.ruleCategoryIndex))
+		for c := range a.index.ruleCategoryIndex {
+			categories = append(categories, c)
+		}
+		sort.Strings(categories)
+		return categories
+	}
+
 	var categories []string
 	rows, err := a.db.Query(`SELECT DISTINCT category FROM rules WHERE category != '' ORDER BY category`)
 	if err != nil {
@@ -319,6 +352,12 @@ func (a *App) getRuleCategories() []string {
 }
 
 func (a *App) getRulesByCategory(category string) []string {
+	if a.index != nil {
+		rules := a.index.ruleCategoryIndex[category].List()
+		sort.Strings(rules)
+		return rules
+	}
+
 	var rules []string
 	rows, err := a.db.Query(`SELECT rule_id FROM rules WHERE category = ? ORDER BY rule_id`, category)
 	if err != nil {
@@ -336,6 +375,10 @@ func (a *App) getRulesByCategory(category string) []string {
 
 // GetElement returns full element info by name
 func (a *App) GetElement(name string) *ElementInfo {
+	if a.index != nil {
+		return a.index.byName[name]
+	}
+
 	var e ElementInfo
 	err := a.db.QueryRow(`
 		SELECT id, name, COALESCE(namespace, ''), COALESCE(since_version, ''),
@@ -482,85 +525,26 @@ func (a *App) GetRule(ruleID string) *RuleInfo {
 	return &r
 }
 
-// Search searches across all content
-func (a *App) Search(query string) []SearchResult {
-	var results []SearchResult
-
-	if query == "" {
-		return results
+// Search ranks query (via FTS5/BM25, falling back to a LIKE scan for
+// older wix.db files) across elements, attributes, snippets, errors, ICE
+// rules and lint rules. The LIKE fallback covers elements, snippets,
+// errors and lint rules only. See internal/search for the query syntax.
+func (a *App) Search(query string) []search.Result {
+	if a.search == nil {
+		return nil
 	}
+	return a.search.Search(query)
+}
 
-	likeQuery := "%" + strings.ToLower(query) + "%"
-
-	// Search elements
-	rows, _ := a.db.Query(`
-		SELECT name, 'element', namespace, COALESCE(description, '')
-		FROM elements
-		WHERE LOWER(name) LIKE ? OR LOWER(description) LIKE ?
-		LIMIT 20
-	`, likeQuery, likeQuery)
-	if rows != nil {
-		for rows.Next() {
-			var r SearchResult
-			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
-			r.ID = "element:" + r.Name
-			results = append(results, r)
-		}
-		rows.Close()
-	}
-
-	// Search snippets
-	rows, _ = a.db.Query(`
-		SELECT name, 'snippet', COALESCE(scope, ''), COALESCE(description, '')
-		FROM snippets
-		WHERE LOWER(name) LIKE ? OR LOWER(description) LIKE ?
-		LIMIT 10
-	`, likeQuery, likeQuery)
-	if rows != nil {
-		for rows.Next() {
-			var r SearchResult
-			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
-			r.ID = "snippet:" + r.Name
-			results = append(results, r)
-		}
-		rows.Close()
-	}
-
-	// Search errors
-	rows, _ = a.db.Query(`
-		SELECT code, 'wix-error', severity, COALESCE(description, '')
-		FROM errors
-		WHERE LOWER(code) LIKE ? OR LOWER(description) LIKE ?
-		LIMIT 10
-	`, likeQuery, likeQuery)
-	if rows != nil {
-		for rows.Next() {
-			var r SearchResult
-			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
-			r.ID = "error:" + r.Name
-			results = append(results, r)
-		}
-		rows.Close()
-	}
-
-	// Search rules
-	rows, _ = a.db.Query(`
-		SELECT rule_id, 'rule', COALESCE(category, ''), COALESCE(description, '')
-		FROM rules
-		WHERE LOWER(rule_id) LIKE ? OR LOWER(name) LIKE ? OR LOWER(description) LIKE ?
-		LIMIT 10
-	`, likeQuery, likeQuery, likeQuery)
-	if rows != nil {
-		for rows.Next() {
-			var r SearchResult
-			rows.Scan(&r.Name, &r.Type, &r.Category, &r.Synopsis)
-			r.ID = "rule:" + r.Name
-			results = append(results, r)
-		}
-		rows.Close()
+// SearchStatus returns a non-empty explanation when Search is running on
+// the unranked LIKE fallback instead of FTS5/BM25 (e.g. because this
+// binary wasn't built with `-tags sqlite_fts5`), so the UI can surface
+// it instead of it sitting silently in the startup log.
+func (a *App) SearchStatus() string {
+	if a.search == nil {
+		return ""
 	}
-
-	return results
+	return a.search.Degraded()
 }
 
 // GetStats returns database statistics
@@ -584,3 +568,66 @@ func (a *App) GetStats() map[string]int {
 
 	return stats
 }
+
+// LintFile loads a .wxs/.wxi file from disk and lints it.
+func (a *App) LintFile(path string) []lint.Diagnostic {
+	if a.linter == nil {
+		return []lint.Diagnostic{{Severity: lint.SeverityError, Message: "linter not initialized: database not loaded"}}
+	}
+	diags, err := a.linter.LintFile(path)
+	if err != nil {
+		return []lint.Diagnostic{{Severity: lint.SeverityError, Message: err.Error()}}
+	}
+	return diags
+}
+
+// LintSource lints an in-memory .wxs/.wxi document, e.g. an unsaved editor buffer.
+func (a *App) LintSource(xml string) []lint.Diagnostic {
+	if a.linter == nil {
+		return []lint.Diagnostic{{Severity: lint.SeverityError, Message: "linter not initialized: database not loaded"}}
+	}
+	return a.linter.LintSource(xml)
+}
+
+// DiagnosticRef resolves a diagnostic code to the full KB record it came
+// from, so the tree/search UI can jump straight to the matching detail view.
+type DiagnosticRef struct {
+	Code  string       `json:"code"`
+	Kind  string       `json:"kind"`
+	Rule  *RuleInfo    `json:"rule,omitempty"`
+	Error *ErrorInfo   `json:"error,omitempty"`
+	Ice   *IceRuleInfo `json:"ice_rule,omitempty"`
+}
+
+// GetDiagnostic resolves a lint diagnostic code (a rule_id, a WiX error
+// code, or an ICE code) to its full KB record.
+func (a *App) GetDiagnostic(code string) *DiagnosticRef {
+	if r := a.GetRule(code); r != nil {
+		return &DiagnosticRef{Code: code, Kind: "rule", Rule: r}
+	}
+	if e := a.GetWixError(code); e != nil {
+		return &DiagnosticRef{Code: code, Kind: "error", Error: e}
+	}
+	if r := a.GetIceRule(code); r != nil {
+		return &DiagnosticRef{Code: code, Kind: "ice-rule", Ice: r}
+	}
+	return nil
+}
+
+// ServeHTTP starts the REST API on addr, exposing the same KB data this
+// App serves to the Wails UI as JSON over HTTP for web UIs and CI tooling.
+// It blocks until the server stops or fails.
+func (a *App) ServeHTTP(addr string) error {
+	server, err := httpapi.NewServer(a.db, a.dbPath)
+	if err != nil {
+		return err
+	}
+	return server.ListenAndServe(addr)
+}
+
+// ExportBundle walks the whole KB and writes it to outPath as a single
+// portable zip archive, rendered as format ("json" or "markdown"), for
+// users who want a diffable, greppable snapshot without the app.
+func (a *App) ExportBundle(outPath string, format string) error {
+	return bundle.Export(a.db, outPath, bundle.Format(format))
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/Tsahi-Elkayam/wixcraft/internal/lint"
+	"github.com/Tsahi-Elkayam/wixcraft/internal/search"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// elementIndex is the in-memory snapshot built once at startup (and
+// rebuilt on Reload) so that sidebar construction and repeated GetElement
+// calls don't each cost a SQL round trip.
+type elementIndex struct {
+	byName      map[string]*ElementInfo
+	byNamespace map[string][]string
+	namespaces  []string
+
+	// attrIndex maps an attribute name to the set of elements that carry it.
+	attrIndex map[string]set[string]
+	// childIndex maps a child element name to the set of elements that
+	// allow it as a child, i.e. the reverse of ElementInfo.Children.
+	childIndex map[string]set[string]
+	// ruleCategoryIndex maps a rule category to the set of rule IDs in it.
+	ruleCategoryIndex map[string]set[string]
+	// ruleTargetIndex maps a RuleInfo.TargetName (an element or attribute
+	// name) to the rules that check it.
+	ruleTargetIndex map[string][]RuleInfo
+}
+
+// buildIndex loads the full element/attribute/rule schema from the
+// database once, so later lookups are map reads instead of queries.
+func (a *App) buildIndex() (*elementIndex, error) {
+	idx := &elementIndex{
+		byName:            map[string]*ElementInfo{},
+		byNamespace:       map[string][]string{},
+		attrIndex:         map[string]set[string]{},
+		childIndex:        map[string]set[string]{},
+		ruleCategoryIndex: map[string]set[string]{},
+		ruleTargetIndex:   map[string][]RuleInfo{},
+	}
+
+	rows, err := a.db.Query(`
+		SELECT id, name, COALESCE(namespace, ''), COALESCE(since_version, ''),
+			COALESCE(description, ''), COALESCE(documentation_url, ''), COALESCE(remarks, '')
+		FROM elements ORDER BY namespace, name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("index: reading elements: %w", err)
+	}
+	var elements []*ElementInfo
+	for rows.Next() {
+		e := &ElementInfo{}
+		if err := rows.Scan(&e.ID, &e.Name, &e.Namespace, &e.SinceVersion, &e.Description, &e.Documentation, &e.Remarks); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("index: scanning element: %w", err)
+		}
+		elements = append(elements, e)
+	}
+	rows.Close()
+
+	for _, e := range elements {
+		e.Parents = a.getElementParents(e.ID)
+		e.Children = a.getElementChildren(e.ID)
+		e.Attributes = a.getElementAttributes(e.ID)
+
+		idx.byName[e.Name] = e
+		idx.byNamespace[e.Namespace] = append(idx.byNamespace[e.Namespace], e.Name)
+
+		for _, attr := range e.Attributes {
+			indexAdd(idx.attrIndex, attr.Name, e.Name)
+		}
+		for _, child := range e.Children {
+			indexAdd(idx.childIndex, child, e.Name)
+		}
+	}
+
+	for ns := range idx.byNamespace {
+		if ns != "" {
+			idx.namespaces = append(idx.namespaces, ns)
+		}
+	}
+	sort.Strings(idx.namespaces)
+
+	ruleRows, err := a.db.Query(`
+		SELECT id, rule_id, COALESCE(category, ''), COALESCE(severity, ''), COALESCE(name, ''),
+			COALESCE(description, ''), COALESCE(rationale, ''), COALESCE(fix_suggestion, ''), COALESCE(target_name, '')
+		FROM rules
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("index: reading rules: %w", err)
+	}
+	defer ruleRows.Close()
+	for ruleRows.Next() {
+		var r RuleInfo
+		if err := ruleRows.Scan(&r.ID, &r.RuleID, &r.Category, &r.Severity, &r.Name,
+			&r.Description, &r.Rationale, &r.FixSuggestion, &r.TargetName); err != nil {
+			return nil, fmt.Errorf("index: scanning rule: %w", err)
+		}
+		if r.Category != "" {
+			indexAdd(idx.ruleCategoryIndex, r.Category, r.RuleID)
+		}
+		if r.TargetName != "" {
+			idx.ruleTargetIndex[r.TargetName] = append(idx.ruleTargetIndex[r.TargetName], r)
+		}
+	}
+
+	return idx, nil
+}
+
+func indexAdd(index map[string]set[string], key, value string) {
+	s, ok := index[key]
+	if !ok {
+		s = newSet[string]()
+		index[key] = s
+	}
+	s.Add(value)
+}
+
+// FindElementsWithAttribute returns every element that declares an
+// attribute named name, using the reverse index built at startup.
+func (a *App) FindElementsWithAttribute(name string) []string {
+	if a.index == nil {
+		return nil
+	}
+	names := a.index.attrIndex[name].List()
+	sort.Strings(names)
+	return names
+}
+
+// FindElementsAllowingChild returns every element that allows name as a
+// child, using the reverse index built at startup.
+func (a *App) FindElementsAllowingChild(name string) []string {
+	if a.index == nil {
+		return nil
+	}
+	names := a.index.childIndex[name].List()
+	sort.Strings(names)
+	return names
+}
+
+// RulesTargeting returns every lint rule whose TargetName matches name
+// (an element or attribute name), using the reverse index built at startup.
+func (a *App) RulesTargeting(name string) []RuleInfo {
+	if a.index == nil {
+		return nil
+	}
+	return a.index.ruleTargetIndex[name]
+}
+
+// Reload re-opens the database and rebuilds every cache (the element
+// index, the linter schema and the search index), picking up a wix.db
+// that changed on disk without restarting the app.
+func (a *App) Reload() error {
+	if a.db != nil {
+		a.db.Close()
+	}
+
+	db, err := sql.Open("sqlite3", a.dbPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("reload: opening database: %w", err)
+	}
+	a.db = db
+
+	linter, err := lint.NewLinter(db)
+	if err != nil {
+		return fmt.Errorf("reload: loading linter schema: %w", err)
+	}
+	a.linter = linter
+	a.search = search.NewEngine(db)
+
+	index, err := a.buildIndex()
+	if err != nil {
+		return fmt.Errorf("reload: building index: %w", err)
+	}
+	a.index = index
+
+	return nil
+}
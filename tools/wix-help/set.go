@@ -0,0 +1,57 @@
+package main
+
+// set is a minimal generic set, used by the in-memory reverse indexes
+// in index.go to answer "which elements have attribute X" / "which rules
+// are in category Y" style queries in O(1) instead of a SQL round trip.
+type set[T comparable] struct {
+	m map[T]struct{}
+}
+
+func newSet[T comparable](items ...T) set[T] {
+	s := set[T]{m: make(map[T]struct{}, len(items))}
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+// Add is a value receiver because the map it mutates is itself a
+// reference type: a copy of set[T] still shares the same underlying map.
+func (s set[T]) Add(v T) {
+	s.m[v] = struct{}{}
+}
+
+func (s set[T]) Has(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+func (s set[T]) List() []T {
+	out := make([]T, 0, len(s.m))
+	for v := range s.m {
+		out = append(out, v)
+	}
+	return out
+}
+
+func (s set[T]) Intersect(other set[T]) set[T] {
+	result := newSet[T]()
+	small, big := s, other
+	if len(big.m) < len(small.m) {
+		small, big = big, small
+	}
+	for v := range small.m {
+		if big.Has(v) {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+func (s set[T]) Union(other set[T]) set[T] {
+	result := newSet(s.List()...)
+	for v := range other.m {
+		result.Add(v)
+	}
+	return result
+}